@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var findCmd = &cobra.Command{
+	Use:   "find <pattern>",
+	Short: "Fuzzy-find repos by import path",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projects := scanProjects()
+		for _, repo := range projects.Repos("", "") {
+			if fuzzyMatch(args[0], repo.ImportPath()) {
+				fmt.Println(repo.ImportPath())
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(findCmd)
+}
+
+// fuzzyMatch reports whether every character of pattern appears in s,
+// in order, allowing arbitrary characters in between (a subsequence
+// match, case-insensitive).
+func fuzzyMatch(pattern, s string) bool {
+	pattern = strings.ToLower(pattern)
+	s = strings.ToLower(s)
+
+	i := 0
+	for _, r := range s {
+		if i == len(pattern) {
+			break
+		}
+		if rune(pattern[i]) == r {
+			i++
+		}
+	}
+	return i == len(pattern)
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cizixs/gpm/internal/scan"
+	"github.com/go-git/go-git/v5"
+	"github.com/spf13/cobra"
+)
+
+var (
+	getUpdate  bool
+	getClean   bool
+	getDryRun  bool
+	getVerbose bool
+)
+
+var getCmd = &cobra.Command{
+	Use:   "get <importpath>...",
+	Short: "Fetch (or update) go-gettable repos into GOPATH",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, importPath := range args {
+			if err := getOne(importPath); err != nil {
+				return fmt.Errorf("%s: %w", importPath, err)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	getCmd.Flags().BoolVarP(&getUpdate, "update", "u", false, "update an already-cloned repo instead of failing")
+	getCmd.Flags().BoolVar(&getClean, "clean", false, "remove untracked files after fetching or updating")
+	getCmd.Flags().BoolVar(&getDryRun, "dry-run", false, "print what would happen without cloning, pulling, or cleaning")
+	getCmd.Flags().BoolVarP(&getVerbose, "verbose", "v", false, "print each step (clone/update/clean) as it happens")
+	rootCmd.AddCommand(getCmd)
+}
+
+// getOne clones importPath into $GOPATH/src/<importpath>, or updates it
+// in place if it is already cached and -u was given. The on-disk
+// Projects index is consulted (and updated) so repeated invocations
+// don't need a fresh GOPATH walk just to know what's already cloned.
+func getOne(importPath string) error {
+	index, err := scan.LoadIndex("")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(gopathFirst(), "src", importPath)
+	cachedRepo, cached := indexLookup(index, importPath)
+	if cached && cachedRepo.Dir != "" && !isDir(cachedRepo.Dir) {
+		// The index is stale: the repo is gone from disk (e.g. it was
+		// moved aside outside of `gpm rm`). Treat it as not cloned
+		// instead of trusting the cache, and drop the dangling entry.
+		index.RemoveRepo(importPath)
+		cached = false
+	}
+	exists := cached || isDir(dir)
+
+	if exists && !getUpdate {
+		return fmt.Errorf("%s already exists (use -u to update)", dir)
+	}
+
+	if getDryRun {
+		if exists {
+			fmt.Printf("would update %s\n", dir)
+		} else {
+			fmt.Printf("would clone %s into %s\n", importPath, dir)
+		}
+		return nil
+	}
+
+	if exists {
+		if getVerbose {
+			fmt.Printf("%s (update)\n", importPath)
+		}
+		if err := updateRepo(dir); err != nil {
+			return err
+		}
+	} else {
+		if getVerbose {
+			fmt.Printf("%s (download)\n", importPath)
+		}
+		if err := cloneRepo(importPath, dir); err != nil {
+			return err
+		}
+	}
+
+	if getClean {
+		if getVerbose {
+			fmt.Printf("%s (clean)\n", importPath)
+		}
+		if err := cleanRepo(dir); err != nil {
+			return err
+		}
+	}
+
+	repo, err := scan.ParsePath(filepath.Join(gopathFirst(), "src"), dir)
+	if err != nil {
+		return err
+	}
+	repo.Kind = scan.KindGit
+	repo.Dir = dir
+	index.AddRepo(repo)
+	return index.Save("")
+}
+
+// cloneRepo clones importPath's https remote into dir using go-git, so
+// gpm doesn't need an external git binary.
+func cloneRepo(importPath, dir string) error {
+	if err := os.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+		return err
+	}
+	_, err := git.PlainClone(dir, false, &git.CloneOptions{
+		URL: "https://" + importPath,
+	})
+	return err
+}
+
+// updateRepo runs the equivalent of `git pull --ff-only` on dir: go-git's
+// Pull only ever fast-forwards the current branch.
+func updateRepo(dir string) error {
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		return err
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+
+	err = wt.Pull(&git.PullOptions{SingleBranch: true})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return err
+	}
+	return nil
+}
+
+// cleanRepo removes untracked files from dir's working copy.
+func cleanRepo(dir string) error {
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		return err
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+	return wt.Clean(&git.CleanOptions{Dir: true})
+}
+
+func indexLookup(index *scan.Projects, importPath string) (scan.Repo, bool) {
+	for _, repo := range index.Repos("", "") {
+		if repo.ImportPath() == importPath {
+			return repo, true
+		}
+	}
+	return scan.Repo{}, false
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var gotoCmd = &cobra.Command{
+	Use:   "goto <pattern>",
+	Short: `Print the absolute path of a repo, for use with cd "$(gpm goto ...)"`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projects := scanProjects()
+		for _, repo := range projects.Repos("", "") {
+			if fuzzyMatch(args[0], repo.ImportPath()) {
+				fmt.Println(repo.Dir)
+				return nil
+			}
+		}
+		return fmt.Errorf("no repo matching %q found", args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(gotoCmd)
+}
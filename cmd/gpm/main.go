@@ -0,0 +1,32 @@
+/*
+gpm stands for go Projects manager.
+
+It provides the basic functionality to visually manage go Projects
+under `$GOPATH/src` and the go module cache. You can list, find, remove,
+and goto Projects easily without tediously `cd` to the target path and
+type long commands which can be error prune.
+
+	gpm source list
+	gpm owner list --source=github.com
+	gpm repo list --source=github.com --owner=cizixs
+
+	gpm find gpm
+	gpm rm github.com/cizixs/gpm
+	gpm goto gpm
+
+	gpm --help
+
+`gpm goto` prints the absolute path of the matched repo so it can be
+combined with shell command substitution:
+
+	cd "$(gpm goto gpm)"
+
+Shell completion is provided by cobra's built-in `completion` command;
+pre-generated scripts for bash, zsh, and fish also live under
+completions/ (regenerate with scripts/gen-completions.sh).
+*/
+package main
+
+func main() {
+	Execute()
+}
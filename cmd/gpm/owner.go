@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var ownerSource string
+
+var ownerCmd = &cobra.Command{
+	Use:   "owner",
+	Short: "Manage go Projects owners",
+}
+
+var ownerListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all owners found under GOPATH",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projects := scanProjects()
+
+		seen := make(map[string]bool)
+		for _, repo := range projects.Repos(ownerSource, "") {
+			if seen[repo.Owner] {
+				continue
+			}
+			seen[repo.Owner] = true
+			fmt.Println(repo.Owner)
+		}
+		return nil
+	},
+}
+
+func init() {
+	ownerListCmd.Flags().StringVar(&ownerSource, "source", "", "only list owners from this source")
+	ownerCmd.AddCommand(ownerListCmd)
+	rootCmd.AddCommand(ownerCmd)
+}
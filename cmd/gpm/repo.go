@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/cizixs/gpm/internal/source"
+	"github.com/spf13/cobra"
+)
+
+var (
+	repoSource string
+	repoOwner  string
+	repoOutput string
+)
+
+var repoCmd = &cobra.Command{
+	Use:   "repo",
+	Short: "Manage go Projects repos",
+}
+
+var repoListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all repos found under GOPATH",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projects := scanProjects()
+		for _, repo := range projects.Repos(repoSource, repoOwner) {
+			switch repoOutput {
+			case "", "importpath":
+				fmt.Println(repo.ImportPath())
+			case "url":
+				repoURL, err := source.RepoURL(repo)
+				if err != nil {
+					return err
+				}
+				fmt.Println(repoURL)
+			default:
+				return fmt.Errorf("unknown -o format %q", repoOutput)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	repoListCmd.Flags().StringVar(&repoSource, "source", "", "only list repos from this source")
+	repoListCmd.Flags().StringVar(&repoOwner, "owner", "", "only list repos from this owner")
+	repoListCmd.Flags().StringVarP(&repoOutput, "output", "o", "importpath", `output format: "importpath" or "url"`)
+	repoCmd.AddCommand(repoListCmd)
+	rootCmd.AddCommand(repoCmd)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/cizixs/gpm/internal/scan"
+	"github.com/spf13/cobra"
+)
+
+var rmCmd = &cobra.Command{
+	Use:   "rm <importpath>",
+	Short: "Remove a go-gettable repo that has no local changes",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return removeRepo(args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rmCmd)
+}
+
+// removeRepo moves the repo at importPath into gpm's trash directory,
+// refusing to do so if the working copy has local changes, and prunes
+// the matching entry (if any) from the on-disk Projects index so a
+// later `gpm get` doesn't think the repo is still cloned.
+func removeRepo(importPath string) error {
+	index, err := scan.LoadIndex("")
+	if err != nil {
+		return err
+	}
+
+	dir, kind := repoDir(index, importPath)
+	if !isDir(dir) {
+		return fmt.Errorf("%s: not a directory", dir)
+	}
+
+	clean, err := isVCSClean(dir, kind)
+	if err != nil {
+		return err
+	}
+	if !clean {
+		return fmt.Errorf("%s has local changes, refusing to remove", importPath)
+	}
+
+	dest := filepath.Join(trashRoot(), fmt.Sprintf("%d-%s", time.Now().Unix(), filepath.Base(dir)))
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return err
+	}
+	if err := os.Rename(dir, dest); err != nil {
+		return err
+	}
+
+	if index.RemoveRepo(importPath) {
+		return index.Save("")
+	}
+	return nil
+}
+
+// repoDir resolves importPath to the directory it actually lives in,
+// and the VCS kind it was found as (empty if that's unknown, i.e. the
+// legacy guess below). The cached index is consulted first, since it
+// tracks repos found anywhere under GOPATH, including the module
+// cache, where the directory can't be reconstructed from importPath
+// alone. Falling back to a fresh walk (bypassing the index, which just
+// missed) covers repos that haven't been indexed yet; the legacy
+// $GOPATH/src/<importpath> guess is the last resort for a repo neither
+// has seen.
+func repoDir(index *scan.Projects, importPath string) (string, scan.Kind) {
+	if repo, ok := indexLookup(index, importPath); ok && repo.Dir != "" {
+		return repo.Dir, repo.Kind
+	}
+	for _, repo := range walkProjects().Repos("", "") {
+		if repo.ImportPath() == importPath {
+			return repo.Dir, repo.Kind
+		}
+	}
+	return filepath.Join(gopathFirst(), "src", importPath), ""
+}
+
+// isVCSClean reports whether dir, a working copy of the given kind,
+// has no local changes. kind is empty for the legacy $GOPATH/src guess
+// that never ran through a detector; that case (and any VCS this repo
+// has no status check for) falls back to probing for a .git directory,
+// same as before gpm knew about hg/bzr. Directories with no VCS at all
+// are treated as always clean.
+func isVCSClean(dir string, kind scan.Kind) (bool, error) {
+	switch kind {
+	case scan.KindHg:
+		return vcsStatusClean(dir, "hg", "status")
+	case scan.KindBzr:
+		return vcsStatusClean(dir, "bzr", "status")
+	}
+
+	if !isDir(filepath.Join(dir, ".git")) {
+		return true, nil
+	}
+	return vcsStatusClean(dir, "git", "status", "--porcelain")
+}
+
+// vcsStatusClean runs a VCS's status command in dir and reports
+// whether it produced no output, which every VCS gpm supports uses to
+// mean "nothing to commit".
+func vcsStatusClean(dir, name string, args ...string) (bool, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return false, fmt.Errorf("%s status in %s: %w", name, dir, err)
+	}
+	return out.Len() == 0, nil
+}
+
+func trashRoot() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+	return filepath.Join(cacheDir, "gpm", "trash")
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return info.IsDir()
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cizixs/gpm/internal/scan"
+	"github.com/spf13/cobra"
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "gpm",
+	Short: "gpm manages go Projects under $GOPATH",
+}
+
+var (
+	scanJobs     int
+	scanIgnore   []string
+	scanProgress bool
+	scanRefresh  bool
+)
+
+func init() {
+	rootCmd.PersistentFlags().IntVar(&scanJobs, "jobs", 0, "number of concurrent scan workers (default: GOMAXPROCS)")
+	rootCmd.PersistentFlags().StringArrayVar(&scanIgnore, "ignore", nil, "glob pattern of directory names to skip while scanning (repeatable)")
+	rootCmd.PersistentFlags().BoolVar(&scanProgress, "progress", false, "print scan progress to stderr")
+	rootCmd.PersistentFlags().BoolVar(&scanRefresh, "refresh", false, "re-walk GOPATH instead of using the cached repo index")
+}
+
+// Execute runs the root command and exits the process on error.
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// gopath returns the GOPATH to scan. It may be a colon-separated list of
+// entries (see `go help gopath`); scan.Walk knows how to split it, but
+// callers that need a single directory to join a path onto should use
+// gopathFirst instead.
+func gopath() string {
+	return os.Getenv("GOPATH")
+}
+
+// gopathFirst returns the first entry of GOPATH, the directory `go get`
+// itself clones into for a multi-entry GOPATH. Unlike gopath, it is safe
+// to filepath.Join onto: joining the raw, unsplit GOPATH string glues a
+// colon-separated list into one bogus path component.
+func gopathFirst() string {
+	entries := filepath.SplitList(gopath())
+	if len(entries) == 0 {
+		return ""
+	}
+	return entries[0]
+}
+
+// scanProjects returns the cached repo index when it reflects a full
+// GOPATH walk (see scan.Projects.Complete), falling back to (and
+// populating) a fresh walkProjects otherwise: an index built only from
+// `gpm get`/`gpm rm` bookkeeping, with no walk behind it, can't stand
+// in for one. --refresh forces a fresh walk regardless of what's
+// cached, for callers that need up-to-date results (e.g. after adding
+// a repo outside of gpm).
+func scanProjects() *scan.Projects {
+	if !scanRefresh {
+		if index, err := scan.LoadIndex(""); err == nil && index.Complete() {
+			return index
+		}
+	}
+	return walkProjects()
+}
+
+// walkProjects walks GOPATH using the --jobs/--ignore/--progress flags
+// shared by every subcommand, and saves the result to the on-disk
+// index so later scanProjects calls (and `gpm get`) don't need a fresh
+// walk just to know what's already there.
+func walkProjects() *scan.Projects {
+	opts := scan.Options{
+		Jobs:   scanJobs,
+		Ignore: scanIgnore,
+	}
+	if scanProgress {
+		n := 0
+		opts.Progress = func(dir string) {
+			n++
+			if n%200 == 0 {
+				fmt.Fprintf(os.Stderr, "\rscanned %d directories", n)
+			}
+		}
+	}
+
+	projects := scan.Walk(gopath(), opts)
+	if scanProgress {
+		fmt.Fprintln(os.Stderr)
+	}
+	if err := projects.Save(""); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to update repo index: %v\n", err)
+	}
+	return projects
+}
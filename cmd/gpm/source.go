@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var sourceCmd = &cobra.Command{
+	Use:   "source",
+	Short: "Manage go Projects sources",
+}
+
+var sourceListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all sources found under GOPATH",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projects := scanProjects()
+		for _, source := range projects.Sources() {
+			fmt.Println(source)
+		}
+		return nil
+	},
+}
+
+func init() {
+	sourceCmd.AddCommand(sourceListCmd)
+	rootCmd.AddCommand(sourceCmd)
+}
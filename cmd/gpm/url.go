@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cizixs/gpm/internal/source"
+	"github.com/spf13/cobra"
+)
+
+var urlCmd = &cobra.Command{
+	Use:   "url <importpath> [file[:line]]",
+	Short: "Print the upstream URL of a repo, file, or line",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projects := scanProjects()
+		for _, repo := range projects.Repos("", "") {
+			if repo.ImportPath() != args[0] {
+				continue
+			}
+
+			if len(args) == 1 {
+				repoURL, err := source.RepoURL(repo)
+				if err != nil {
+					return err
+				}
+				fmt.Println(repoURL)
+				return nil
+			}
+
+			file, line := parseFileLine(args[1])
+			fileURL, err := source.FileURL(repo, file, line)
+			if err != nil {
+				return err
+			}
+			fmt.Println(fileURL)
+			return nil
+		}
+		return fmt.Errorf("no repo matching %q found", args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(urlCmd)
+}
+
+// parseFileLine splits "file.go:42" into ("file.go", 42). If there is
+// no ":line" suffix, or it isn't a number, line is 0.
+func parseFileLine(arg string) (file string, line int) {
+	idx := strings.LastIndex(arg, ":")
+	if idx < 0 {
+		return arg, 0
+	}
+	if n, err := strconv.Atoi(arg[idx+1:]); err == nil {
+		return arg[:idx], n
+	}
+	return arg, 0
+}
@@ -0,0 +1,142 @@
+package scan
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Detector inspects a candidate directory during a walk. root is the
+// `GOPATH/src` or `GOPATH/pkg/mod` directory the walk started from,
+// used to classify dir's path into source/owner/repo. Detect reports
+// the Repo found there (if any) and whether the walker should stop
+// descending into the directory's children regardless of whether a
+// repo was found.
+type Detector interface {
+	Detect(root, dir string) (repo Repo, found, skip bool)
+}
+
+// gitDetector finds directories that are the root of a git working
+// copy.
+type gitDetector struct{}
+
+func (gitDetector) Detect(root, dir string) (Repo, bool, bool) {
+	if !isDir(filepath.Join(dir, ".git")) {
+		return Repo{}, false, false
+	}
+	return detectedRepo(root, dir, KindGit)
+}
+
+// hgDetector finds directories that are the root of a Mercurial
+// working copy.
+type hgDetector struct{}
+
+func (hgDetector) Detect(root, dir string) (Repo, bool, bool) {
+	if !isDir(filepath.Join(dir, ".hg")) {
+		return Repo{}, false, false
+	}
+	return detectedRepo(root, dir, KindHg)
+}
+
+// bzrDetector finds directories that are the root of a Bazaar working
+// copy.
+type bzrDetector struct{}
+
+func (bzrDetector) Detect(root, dir string) (Repo, bool, bool) {
+	if !isDir(filepath.Join(dir, ".bzr")) {
+		return Repo{}, false, false
+	}
+	return detectedRepo(root, dir, KindBzr)
+}
+
+// goModDetector finds directories that are the root of a Go module,
+// i.e. contain a go.mod file, regardless of which (if any) VCS they
+// use.
+type goModDetector struct{}
+
+func (goModDetector) Detect(root, dir string) (Repo, bool, bool) {
+	if !isGoModule(dir) {
+		return Repo{}, false, false
+	}
+	return detectedRepo(root, dir, KindModule)
+}
+
+// gopathDetector is the fallback for plain package directories under
+// $GOPATH/src that have no VCS marker or go.mod: without a better
+// signal, it treats the source/owner/repo boundary (three path
+// segments below root) as the repo root, matching the common
+// three-segment import path shape (e.g. github.com/owner/repo). Like
+// ParsePath's own three-segment fallback, this is a guess that can be
+// wrong for two-segment hosts (gopkg.in/pkg.vN) with a deep, VCS-less
+// subpath; it only runs once every more specific detector has passed.
+type gopathDetector struct{}
+
+func (gopathDetector) Detect(root, dir string) (Repo, bool, bool) {
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return Repo{}, false, false
+	}
+	if len(strings.Split(filepath.ToSlash(rel), "/")) != 3 {
+		return Repo{}, false, false
+	}
+	return detectedRepo(root, dir, KindGopath)
+}
+
+// vendorDetector stops the walker from descending into vendor
+// directories: vendored dependencies are not independent Projects, and
+// walking them wastes most of the time spent on a large GOPATH tree.
+type vendorDetector struct{}
+
+func (vendorDetector) Detect(root, dir string) (Repo, bool, bool) {
+	if filepath.Base(dir) != "vendor" {
+		return Repo{}, false, false
+	}
+	return Repo{}, false, true
+}
+
+// moduleCacheDetector stops the walker from descending into
+// $GOPATH/pkg/mod/cache: that tree is Go's own module-download
+// metadata (zips and their hashes under
+// cache/download/<host>/<owner>/<repo>/@v/), not source checkouts, and
+// gopathDetector's three-segment fallback would otherwise misclassify
+// it as a pile of bogus repos.
+type moduleCacheDetector struct{}
+
+func (moduleCacheDetector) Detect(root, dir string) (Repo, bool, bool) {
+	if filepath.Base(root) != "mod" || filepath.Base(filepath.Dir(root)) != "pkg" {
+		return Repo{}, false, false
+	}
+	if rel, err := filepath.Rel(root, dir); err != nil || rel != "cache" {
+		return Repo{}, false, false
+	}
+	return Repo{}, false, true
+}
+
+// detectedRepo classifies dir via ParsePath and tags the result with
+// kind. A classification error (only possible if dir isn't under
+// root) is treated as "stop descending, but found nothing" rather
+// than propagated, since Detector has no error return.
+func detectedRepo(root, dir string, kind Kind) (Repo, bool, bool) {
+	repo, err := ParsePath(root, dir)
+	if err != nil {
+		return Repo{}, false, true
+	}
+	repo.Kind = kind
+	repo.Dir = dir
+	return repo, true, true
+}
+
+// DefaultDetectors are the detectors used by Walk when none are given
+// explicitly. Order matters: the first detector to report found or
+// skip wins, so vendor and module-cache pruning run before the (more
+// expensive) VCS and module checks, and gopathDetector's three-segment
+// guess runs last, only catching what nothing more specific already
+// claimed.
+var DefaultDetectors = []Detector{
+	vendorDetector{},
+	moduleCacheDetector{},
+	gitDetector{},
+	hgDetector{},
+	bzrDetector{},
+	goModDetector{},
+	gopathDetector{},
+}
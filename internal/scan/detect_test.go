@@ -0,0 +1,130 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectors(t *testing.T) {
+	tests := []struct {
+		name      string
+		marker    string // directory marker to create, e.g. ".git"
+		detector  Detector
+		wantKind  Kind
+		wantFound bool
+		wantSkip  bool
+	}{
+		{name: "git", marker: ".git", detector: gitDetector{}, wantKind: KindGit, wantFound: true, wantSkip: true},
+		{name: "hg", marker: ".hg", detector: hgDetector{}, wantKind: KindHg, wantFound: true, wantSkip: true},
+		{name: "bzr", marker: ".bzr", detector: bzrDetector{}, wantKind: KindBzr, wantFound: true, wantSkip: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			src := filepath.Join(root, "src")
+			dir := filepath.Join(src, "example.com", "owner", "repo")
+			if err := os.MkdirAll(filepath.Join(dir, tt.marker), 0o755); err != nil {
+				t.Fatal(err)
+			}
+
+			repo, found, skip := tt.detector.Detect(src, dir)
+			if found != tt.wantFound || skip != tt.wantSkip {
+				t.Fatalf("Detect() = (found=%v, skip=%v), want (found=%v, skip=%v)", found, skip, tt.wantFound, tt.wantSkip)
+			}
+			if repo.Kind != tt.wantKind {
+				t.Errorf("Detect() kind = %q, want %q", repo.Kind, tt.wantKind)
+			}
+			if repo.Source != "example.com" || repo.Owner != "owner" || repo.Repo != "repo" {
+				t.Errorf("Detect() repo = %+v, want source=example.com owner=owner repo=repo", repo)
+			}
+		})
+	}
+}
+
+func TestGoModDetector(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src")
+	dir := filepath.Join(src, "example.com", "owner", "repo")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/owner/repo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo, found, skip := goModDetector{}.Detect(src, dir)
+	if !found || !skip {
+		t.Fatalf("Detect() = (found=%v, skip=%v), want (true, true)", found, skip)
+	}
+	if repo.Kind != KindModule {
+		t.Errorf("Detect() kind = %q, want %q", repo.Kind, KindModule)
+	}
+}
+
+func TestGopathDetector(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src")
+	dir := filepath.Join(src, "example.com", "owner", "repo")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	repo, found, skip := gopathDetector{}.Detect(src, dir)
+	if !found || !skip {
+		t.Fatalf("Detect() = (found=%v, skip=%v), want (true, true)", found, skip)
+	}
+	if repo.Kind != KindGopath {
+		t.Errorf("Detect() kind = %q, want %q", repo.Kind, KindGopath)
+	}
+	if repo.Source != "example.com" || repo.Owner != "owner" || repo.Repo != "repo" {
+		t.Errorf("Detect() repo = %+v, want source=example.com owner=owner repo=repo", repo)
+	}
+
+	_, found, _ = gopathDetector{}.Detect(src, filepath.Join(src, "example.com", "owner"))
+	if found {
+		t.Errorf("Detect() on a two-segment dir found = true, want false (not a source/owner/repo boundary)")
+	}
+}
+
+func TestVendorDetector(t *testing.T) {
+	repo, found, skip := vendorDetector{}.Detect("/some/path", "/some/path/vendor")
+	if found {
+		t.Errorf("vendorDetector.Detect() found = true, want false")
+	}
+	if !skip {
+		t.Errorf("vendorDetector.Detect() skip = false, want true")
+	}
+	if repo != (Repo{}) {
+		t.Errorf("vendorDetector.Detect() repo = %+v, want zero value", repo)
+	}
+
+	_, found, skip = vendorDetector{}.Detect("/some/path", "/some/path/notvendor")
+	if found || skip {
+		t.Errorf("vendorDetector.Detect() on non-vendor dir = (found=%v, skip=%v), want (false, false)", found, skip)
+	}
+}
+
+func TestModuleCacheDetector(t *testing.T) {
+	root := filepath.Join("/some", "gopath", "pkg", "mod")
+
+	_, found, skip := moduleCacheDetector{}.Detect(root, filepath.Join(root, "cache"))
+	if found {
+		t.Errorf("moduleCacheDetector.Detect() found = true, want false")
+	}
+	if !skip {
+		t.Errorf("moduleCacheDetector.Detect() skip = false, want true")
+	}
+
+	_, found, skip = moduleCacheDetector{}.Detect(root, filepath.Join(root, "github.com"))
+	if found || skip {
+		t.Errorf("moduleCacheDetector.Detect() on non-cache dir = (found=%v, skip=%v), want (false, false)", found, skip)
+	}
+
+	src := filepath.Join("/some", "gopath", "src")
+	_, found, skip = moduleCacheDetector{}.Detect(src, filepath.Join(src, "cache"))
+	if found || skip {
+		t.Errorf("moduleCacheDetector.Detect() under a src root = (found=%v, skip=%v), want (false, false)", found, skip)
+	}
+}
@@ -0,0 +1,85 @@
+package scan
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// defaultIndexPath returns the path Save/LoadIndex use when called
+// with an empty path: ~/.cache/gpm/index.json.
+func defaultIndexPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gpm", "index.json"), nil
+}
+
+// indexFile is the on-disk JSON envelope Save/LoadIndex use.
+type indexFile struct {
+	Repos []Repo `json:"repos"`
+	// Complete mirrors Projects.complete: whether Repos is the result
+	// of a full GOPATH walk, safe for a caller to use in place of one.
+	Complete bool `json:"complete,omitempty"`
+}
+
+// Save writes p's repos to path as JSON, so a later LoadIndex call can
+// rebuild the same Projects without re-walking GOPATH. An empty path
+// uses the default cache location.
+func (p *Projects) Save(path string) error {
+	if path == "" {
+		var err error
+		path, err = defaultIndexPath()
+		if err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(indexFile{Repos: p.repos, Complete: p.complete})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// LoadIndex reads a Projects index previously written by Save. An
+// empty path uses the default cache location. A missing index file is
+// not an error: it returns an empty Projects so callers fall back to a
+// fresh Walk.
+func LoadIndex(path string) (*Projects, error) {
+	if path == "" {
+		var err error
+		path, err = defaultIndexPath()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewProjects(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file indexFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		// Older index files were a bare []Repo array; fall back to
+		// that shape rather than treating them as corrupt.
+		if err := json.Unmarshal(data, &file.Repos); err != nil {
+			return nil, err
+		}
+	}
+
+	projects := NewProjects()
+	for _, repo := range file.Repos {
+		projects.AddRepo(repo)
+	}
+	projects.complete = file.Complete
+	return projects, nil
+}
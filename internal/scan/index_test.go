@@ -0,0 +1,108 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveLoadIndexRoundTrip(t *testing.T) {
+	projects := NewProjects()
+	projects.AddRepo(Repo{Source: "github.com", Owner: "cizixs", Repo: "gpm", Kind: KindGit})
+	projects.AddRepo(Repo{Source: "gopkg.in", Owner: "", Repo: "yaml.v2", Kind: KindModule})
+
+	path := filepath.Join(t.TempDir(), "index.json")
+	if err := projects.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadIndex(path)
+	if err != nil {
+		t.Fatalf("LoadIndex() error = %v", err)
+	}
+
+	got := loaded.Repos("", "")
+	want := projects.Repos("", "")
+	if len(got) != len(want) {
+		t.Fatalf("LoadIndex() repos = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("LoadIndex() repo[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSaveLoadIndexPreservesComplete(t *testing.T) {
+	projects := NewProjects()
+	projects.AddRepo(Repo{Source: "github.com", Owner: "cizixs", Repo: "gpm", Kind: KindGit})
+	projects.complete = true
+
+	path := filepath.Join(t.TempDir(), "index.json")
+	if err := projects.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadIndex(path)
+	if err != nil {
+		t.Fatalf("LoadIndex() error = %v", err)
+	}
+	if !loaded.Complete() {
+		t.Errorf("LoadIndex().Complete() = false, want true")
+	}
+}
+
+func TestSaveLoadIndexIncompleteByDefault(t *testing.T) {
+	// Partial bookkeeping built via AddRepo alone (e.g. by `gpm get`),
+	// with no walk behind it, must never round-trip as Complete.
+	projects := NewProjects()
+	projects.AddRepo(Repo{Source: "github.com", Owner: "cizixs", Repo: "gpm", Kind: KindGit})
+
+	path := filepath.Join(t.TempDir(), "index.json")
+	if err := projects.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadIndex(path)
+	if err != nil {
+		t.Fatalf("LoadIndex() error = %v", err)
+	}
+	if loaded.Complete() {
+		t.Errorf("LoadIndex().Complete() = true, want false for partial bookkeeping")
+	}
+}
+
+func TestLoadIndexLegacyBareArray(t *testing.T) {
+	// Before the Complete envelope was added, index.json was a bare
+	// []Repo array. LoadIndex must still accept that shape, treating
+	// it as incomplete since it predates the walk-vs-bookkeeping
+	// distinction.
+	path := filepath.Join(t.TempDir(), "index.json")
+	legacy := `[{"source":"github.com","owner":"cizixs","repo":"gpm","kind":"git","dir":""}]`
+	if err := os.WriteFile(path, []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadIndex(path)
+	if err != nil {
+		t.Fatalf("LoadIndex() error = %v", err)
+	}
+	if got := loaded.Repos("", ""); len(got) != 1 || got[0].ImportPath() != "github.com/cizixs/gpm" {
+		t.Fatalf("LoadIndex() repos = %+v, want one repo github.com/cizixs/gpm", got)
+	}
+	if loaded.Complete() {
+		t.Errorf("LoadIndex().Complete() = true, want false for a legacy bare-array index")
+	}
+}
+
+func TestLoadIndexMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "does-not-exist.json")
+
+	projects, err := LoadIndex(path)
+	if err != nil {
+		t.Fatalf("LoadIndex() error = %v, want nil for missing file", err)
+	}
+	if len(projects.Repos("", "")) != 0 {
+		t.Errorf("LoadIndex() of missing file returned non-empty Projects")
+	}
+}
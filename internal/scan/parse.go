@@ -0,0 +1,90 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// isGoModule reports whether dir looks like the root of a Go module,
+// i.e. it contains a go.mod file.
+func isGoModule(dir string) bool {
+	fileInfo, err := os.Stat(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return false
+	}
+	return !fileInfo.IsDir()
+}
+
+// isRepoRoot reports whether dir is the root of a VCS working copy or
+// a Go module.
+func isRepoRoot(dir string) bool {
+	return isDir(filepath.Join(dir, ".git")) ||
+		isDir(filepath.Join(dir, ".hg")) ||
+		isDir(filepath.Join(dir, ".bzr")) ||
+		isGoModule(dir)
+}
+
+// ParsePath classifies dir - an absolute path somewhere under root,
+// root being a `GOPATH/src` or `GOPATH/pkg/mod` directory - into
+// source, owner, repo, and subpath.
+//
+// Two-segment hosts (gopkg.in/pkg.vN, or any host/repo with no owner)
+// and three-segment hosts (github.com/owner/repo) are recognized
+// directly from the segment count. Deeper paths are ambiguous from the
+// string alone: is "k8s.io/api/core/v1" a two-segment host
+// ("k8s.io/api") with subpath "core/v1", or a three-segment host
+// ("k8s.io/api/core") with subpath "v1"? So for anything deeper than
+// three segments, ParsePath walks upward from dir on disk, looking for
+// the nearest VCS or module root, and uses that boundary instead of
+// guessing.
+func ParsePath(root, dir string) (Repo, error) {
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return Repo{}, err
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+
+	if len(parts) <= 3 {
+		return classify(parts, nil), nil
+	}
+
+	repoParts, subpathParts := splitAtRepoRoot(root, dir, parts)
+	return classify(repoParts, subpathParts), nil
+}
+
+// classify turns the segments naming a repo (1, 2, or 3 of them) plus
+// any remaining subpath segments into a Repo.
+func classify(parts, subpath []string) Repo {
+	var repo Repo
+	switch len(parts) {
+	case 1:
+		repo.Repo = parts[0]
+	case 2:
+		repo.Source, repo.Repo = parts[0], parts[1]
+	default: // 3 or more: github.com/owner/repo, with any extra treated as subpath
+		repo.Source, repo.Owner, repo.Repo = parts[0], parts[1], parts[2]
+		subpath = append(append([]string{}, parts[3:]...), subpath...)
+	}
+	repo.Repo = moduleCacheVersionSuffix(repo.Repo)
+	if len(subpath) > 0 {
+		repo.Subpath = filepath.Join(subpath...)
+	}
+	return repo
+}
+
+// splitAtRepoRoot walks upward from dir, stopping at root, looking for
+// the nearest VCS or module root, and splits parts (dir's path
+// relative to root) at that boundary. If no root is found on disk, it
+// falls back to treating the first three segments as source/owner/repo.
+func splitAtRepoRoot(root, dir string, parts []string) (repoParts, subpathParts []string) {
+	for d, i := dir, len(parts); i > 0 && d != root; d, i = filepath.Dir(d), i-1 {
+		if isRepoRoot(d) {
+			return parts[:i], parts[i:]
+		}
+	}
+	if len(parts) > 3 {
+		return parts[:3], parts[3:]
+	}
+	return parts, nil
+}
@@ -0,0 +1,75 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		name   string
+		dir    string   // path relative to src root
+		marker []string // dirs (relative to src root) that get a .git marker
+		want   Repo
+	}{
+		{
+			name: "github.com",
+			dir:  "github.com/cizixs/gpm",
+			want: Repo{Source: "github.com", Owner: "cizixs", Repo: "gpm"},
+		},
+		{
+			name: "gopkg.in two-segment",
+			dir:  "gopkg.in/yaml.v2",
+			want: Repo{Source: "gopkg.in", Repo: "yaml.v2"},
+		},
+		{
+			name:   "k8s.io single-owner repo with a deep package",
+			dir:    "k8s.io/api/core/v1",
+			marker: []string{"k8s.io/api"},
+			want:   Repo{Source: "k8s.io", Repo: "api", Subpath: "core/v1"},
+		},
+		{
+			name: "golang.org/x/...",
+			dir:  "golang.org/x/net",
+			want: Repo{Source: "golang.org", Owner: "x", Repo: "net"},
+		},
+		{
+			name:   "self-hosted single-segment host",
+			dir:    "rsc.io/quote",
+			marker: []string{"rsc.io/quote"},
+			want:   Repo{Source: "rsc.io", Repo: "quote"},
+		},
+		{
+			name: "deep repo with no detectable root falls back to 3 segments",
+			dir:  "example.com/owner/repo/pkg/sub",
+			want: Repo{Source: "example.com", Owner: "owner", Repo: "repo", Subpath: "pkg/sub"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			src := filepath.Join(root, "src")
+
+			full := filepath.Join(src, filepath.FromSlash(tt.dir))
+			if err := os.MkdirAll(full, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			for _, marker := range tt.marker {
+				if err := os.MkdirAll(filepath.Join(src, filepath.FromSlash(marker), ".git"), 0o755); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := ParsePath(src, full)
+			if err != nil {
+				t.Fatalf("ParsePath() error = %v", err)
+			}
+			got.Kind = "" // Kind is set by the detector, not ParsePath
+			if got != tt.want {
+				t.Errorf("ParsePath(%q) = %+v, want %+v", tt.dir, got, tt.want)
+			}
+		})
+	}
+}
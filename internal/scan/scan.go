@@ -0,0 +1,229 @@
+// Package scan walks $GOPATH (and the Go module cache) and builds an
+// in-memory catalog of the go Projects it finds there. It is the engine
+// behind the `gpm` subcommands.
+package scan
+
+import (
+	"os"
+	"path"
+	"strings"
+)
+
+type sourceType struct {
+	source string
+}
+
+func newSource(source string) *sourceType {
+	return &sourceType{source: source}
+}
+
+type sourcesList struct {
+	sources   map[sourceType]bool
+	maxLength int
+}
+
+func newSourcesList() *sourcesList {
+	s := &sourcesList{}
+	s.sources = make(map[sourceType]bool)
+	s.maxLength = 0
+	return s
+}
+
+func (s *sourcesList) addSource(source *sourceType) {
+	if !s.sources[*source] {
+		s.sources[*source] = true
+	}
+	if len(source.source) > s.maxLength {
+		s.maxLength = len(source.source)
+	}
+}
+
+type ownerType struct {
+	owner  string
+	source string
+}
+
+func newOwner(owner, source string) *ownerType {
+	return &ownerType{
+		owner:  owner,
+		source: source,
+	}
+}
+
+type ownersList struct {
+	owners    map[ownerType]bool
+	maxLength int
+}
+
+func newOwnersList() *ownersList {
+	o := &ownersList{}
+	o.owners = make(map[ownerType]bool)
+	o.maxLength = 0
+	return o
+}
+
+func (o *ownersList) addOwner(owner *ownerType) {
+	if !o.owners[*owner] {
+		o.owners[*owner] = true
+	}
+
+	if len(owner.owner) > o.maxLength {
+		o.maxLength = len(owner.owner)
+	}
+}
+
+// Kind describes how a Repo was discovered.
+type Kind string
+
+const (
+	KindGit    Kind = "git"    // found via a .git directory under $GOPATH/src
+	KindHg     Kind = "hg"     // found via a .hg directory under $GOPATH/src
+	KindBzr    Kind = "bzr"    // found via a .bzr directory under $GOPATH/src
+	KindModule Kind = "module" // found via a go.mod file (pkg/mod cache or elsewhere)
+	KindGopath Kind = "gopath" // plain package directory under $GOPATH/src
+)
+
+// Repo represents a go package found while scanning.
+type Repo struct {
+	Source string `json:"source"`
+	Owner  string `json:"owner"`
+	Repo   string `json:"repo"`
+	Kind   Kind   `json:"kind"`
+	// Dir is the absolute path to the repo's root directory on disk,
+	// e.g. "$GOPATH/src/github.com/cizixs/gpm" or
+	// "$GOPATH/pkg/mod/github.com/sirupsen/logrus@v1.8.1". It reflects
+	// wherever the repo actually lives, since module-cache and other
+	// non-src repos can't be reconstructed from ImportPath alone.
+	Dir string `json:"dir"`
+	// Subpath is the path of a package inside Repo, relative to the
+	// repo root, when the scanned directory is deeper than the repo
+	// root itself (e.g. "core/v1" for "k8s.io/api/core/v1").
+	Subpath string `json:"subpath,omitempty"`
+}
+
+// ImportPath returns the repo's import path, e.g. "github.com/cizixs/gpm".
+func (r Repo) ImportPath() string {
+	return path.Join(r.Source, r.Owner, r.Repo)
+}
+
+// Projects stores structed data for all go packages
+type Projects struct {
+	sources *sourcesList
+	owners  *ownersList
+	repos   []Repo // all repos found in GOPATH
+
+	// complete marks p as the result of a full GOPATH walk, as opposed
+	// to partial bookkeeping accumulated via AddRepo/RemoveRepo alone
+	// (e.g. from `gpm get`/`gpm rm` with no walk behind it). Only a
+	// complete Projects is safe for a caller to use in place of a
+	// fresh walk. Set by Walk; preserved across a Save/LoadIndex
+	// round trip.
+	complete bool
+}
+
+// NewProjects returns a new Projects structure
+func NewProjects() *Projects {
+	p := &Projects{}
+	p.sources = newSourcesList()
+	p.owners = newOwnersList()
+	p.repos = make([]Repo, 0, 10)
+	return p
+}
+
+// AddRepo adds a repo to projects
+func (p *Projects) AddRepo(repo Repo) {
+	p.repos = append(p.repos, repo)
+
+	p.sources.addSource(newSource(repo.Source))
+	p.owners.addOwner(newOwner(repo.Owner, repo.Source))
+}
+
+// RemoveRepo removes the repo matching importPath from p, if present,
+// and reports whether anything was removed. The sources/owners
+// aggregates are rebuilt afterward so a source or owner with no
+// remaining repos doesn't linger in Sources()/Owners().
+func (p *Projects) RemoveRepo(importPath string) bool {
+	removed := false
+	repos := p.repos[:0]
+	for _, repo := range p.repos {
+		if repo.ImportPath() == importPath {
+			removed = true
+			continue
+		}
+		repos = append(repos, repo)
+	}
+	p.repos = repos
+	if !removed {
+		return false
+	}
+
+	p.sources = newSourcesList()
+	p.owners = newOwnersList()
+	for _, repo := range p.repos {
+		p.sources.addSource(newSource(repo.Source))
+		p.owners.addOwner(newOwner(repo.Owner, repo.Source))
+	}
+	return true
+}
+
+// Sources return all sources in a slice
+func (p *Projects) Sources() []string {
+	sources := make([]string, 0, len(p.sources.sources))
+	for source := range p.sources.sources {
+		sources = append(sources, source.source)
+	}
+	return sources
+}
+
+// Owners return all owners in a slice
+func (p *Projects) Owners() []string {
+	owners := make([]string, 0, len(p.owners.owners))
+	for owner := range p.owners.owners {
+		owners = append(owners, owner.owner)
+	}
+	return owners
+}
+
+// Repos returns all repos found, optionally filtered by source and/or
+// owner. An empty filter matches everything.
+func (p *Projects) Repos(source, owner string) []Repo {
+	if source == "" && owner == "" {
+		return p.repos
+	}
+
+	repos := make([]Repo, 0, len(p.repos))
+	for _, repo := range p.repos {
+		if source != "" && repo.Source != source {
+			continue
+		}
+		if owner != "" && repo.Owner != owner {
+			continue
+		}
+		repos = append(repos, repo)
+	}
+	return repos
+}
+
+// Complete reports whether p is the result of a full GOPATH walk (see
+// the complete field), as opposed to partial bookkeeping.
+func (p *Projects) Complete() bool {
+	return p.complete
+}
+
+// isDir checks whether a given path is a valid directory
+func isDir(path string) bool {
+	fileInfo, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return false
+	}
+	return fileInfo.IsDir()
+}
+
+// moduleCacheVersionSuffix strips the `@version` suffix go uses for
+// directories under `$GOPATH/pkg/mod` (e.g. `gpm@v1.2.3` -> `gpm`).
+func moduleCacheVersionSuffix(name string) string {
+	if idx := strings.Index(name, "@"); idx >= 0 {
+		return name[:idx]
+	}
+	return name
+}
@@ -0,0 +1,148 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// Options configures a Walk.
+type Options struct {
+	// Jobs is the number of worker goroutines used to run detectors
+	// concurrently. Zero or negative defaults to GOMAXPROCS.
+	Jobs int
+
+	// Ignore is a list of glob patterns (see filepath.Match), matched
+	// against each directory's base name, to prune from the walk.
+	Ignore []string
+
+	// Detectors are tried, in order, against every candidate
+	// directory; the first to report found or skip wins. Defaults to
+	// DefaultDetectors.
+	Detectors []Detector
+
+	// Progress, if non-nil, is called once for every directory
+	// visited, letting callers drive a progress bar.
+	Progress func(dir string)
+}
+
+func (o Options) jobs() int {
+	if o.Jobs > 0 {
+		return o.Jobs
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+func (o Options) detectors() []Detector {
+	if len(o.Detectors) > 0 {
+		return o.Detectors
+	}
+	return DefaultDetectors
+}
+
+func (o Options) ignored(dir string) bool {
+	base := filepath.Base(dir)
+	for _, pattern := range o.Ignore {
+		if ok, _ := filepath.Match(pattern, base); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Walk scans every entry of gopath (a colon/semicolon-separated list,
+// same format as the GOPATH environment variable), under both `src`
+// and `pkg/mod`, and returns the Projects found there.
+//
+// Each directory is handed to its own goroutine, and at most opts.Jobs
+// of them run opts.Detectors at once; this keeps large trees from being
+// bottlenecked on the syscall latency of a single-threaded walk.
+func Walk(gopath string, opts Options) *Projects {
+	projects := NewProjects()
+	var mu sync.Mutex
+
+	// sem bounds how many directories are run through opts.detectors()
+	// at once; a goroutine is spawned per directory, but most of them
+	// sit waiting to acquire sem, so overall concurrency stays at
+	// opts.jobs() even though a directory can have arbitrarily many
+	// subdirectories.
+	sem := make(chan struct{}, opts.jobs())
+	var wg sync.WaitGroup
+
+	var walkDir func(root, dir string)
+	walkDir = func(root, dir string) {
+		defer wg.Done()
+
+		sem <- struct{}{}
+		defer func() { <-sem }()
+
+		if opts.ignored(dir) {
+			return
+		}
+		if opts.Progress != nil {
+			opts.Progress(dir)
+		}
+
+		for _, detector := range opts.detectors() {
+			repo, found, skip := detector.Detect(root, dir)
+			if found {
+				mu.Lock()
+				projects.AddRepo(repo)
+				mu.Unlock()
+			}
+			if skip {
+				return
+			}
+			if found {
+				break
+			}
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				wg.Add(1)
+				go walkDir(root, filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+
+	for _, entry := range filepath.SplitList(gopath) {
+		if entry == "" {
+			continue
+		}
+		for _, root := range []string{
+			filepath.Join(entry, "src"),
+			filepath.Join(entry, "pkg", "mod"),
+		} {
+			if isDir(root) {
+				wg.Add(1)
+				go walkDir(root, root)
+			}
+		}
+	}
+
+	wg.Wait()
+
+	// The worker pool discovers repos in whatever order goroutines
+	// happen to finish, which varies run to run even over an unchanged
+	// tree. Sort so callers like `gpm goto` get a stable answer.
+	sort.Slice(projects.repos, func(i, j int) bool {
+		return projects.repos[i].ImportPath() < projects.repos[j].ImportPath()
+	})
+
+	projects.complete = true
+	return projects
+}
+
+// GOPATH walks gopath with default options. It is a convenience
+// wrapper around Walk for callers that don't need to tune
+// concurrency, ignore patterns, or detectors.
+func GOPATH(gopath string) *Projects {
+	return Walk(gopath, Options{})
+}
@@ -0,0 +1,63 @@
+package scan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// buildBenchGOPATH creates a synthetic $GOPATH/src tree with
+// hosts*ownersPerHost*reposPerOwner repos (plus their host and owner
+// directories), every one of which is a git repo, to approximate a
+// large real-world workspace. Each repo also gets a few internal
+// subdirectories a naive walk would otherwise descend into, to
+// exercise the VCS-stop pruning under concurrency too.
+func buildBenchGOPATH(b *testing.B, hosts, ownersPerHost, reposPerOwner int) string {
+	b.Helper()
+
+	root := b.TempDir()
+	for h := 0; h < hosts; h++ {
+		for o := 0; o < ownersPerHost; o++ {
+			for r := 0; r < reposPerOwner; r++ {
+				dir := filepath.Join(root, "src", fmt.Sprintf("host%d.example", h), fmt.Sprintf("owner%d", o), fmt.Sprintf("repo%d", r))
+				if err := os.MkdirAll(filepath.Join(dir, ".git"), 0o755); err != nil {
+					b.Fatal(err)
+				}
+				// Repo internals that a naive walk would otherwise descend into.
+				for _, sub := range []string{"pkg/util", "cmd/tool", "vendor/dep"} {
+					if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		}
+	}
+	return root
+}
+
+// benchGOPATHDirCount returns the number of directories
+// buildBenchGOPATH(b, hosts, ownersPerHost, reposPerOwner) creates
+// under src (src itself, each host, each owner, and each repo root -
+// the internal pkg/cmd/vendor subdirectories are never visited, since
+// the walker stops at the repo root).
+func benchGOPATHDirCount(hosts, ownersPerHost, reposPerOwner int) int {
+	return 1 + hosts + hosts*ownersPerHost + hosts*ownersPerHost*reposPerOwner
+}
+
+func BenchmarkWalk(b *testing.B) {
+	const hosts, ownersPerHost, reposPerOwner = 40, 25, 12 // 13,041 directories under src
+	if n := benchGOPATHDirCount(hosts, ownersPerHost, reposPerOwner); n < 10000 {
+		b.Fatalf("synthetic GOPATH has only %d directories, want >10000", n)
+	}
+	root := buildBenchGOPATH(b, hosts, ownersPerHost, reposPerOwner)
+
+	for _, jobs := range []int{1, 4, 8} {
+		jobs := jobs
+		b.Run(fmt.Sprintf("jobs=%d", jobs), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Walk(root, Options{Jobs: jobs})
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func mkrepo(t *testing.T, root, rel string, markers ...string) {
+	t.Helper()
+	dir := filepath.Join(root, rel)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, marker := range markers {
+		if err := os.MkdirAll(filepath.Join(dir, marker), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func importPaths(projects *Projects) []string {
+	paths := make([]string, 0)
+	for _, repo := range projects.Repos("", "") {
+		paths = append(paths, repo.ImportPath())
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func TestWalkFindsGitAndModuleRepos(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src")
+
+	mkrepo(t, src, "github.com/cizixs/gpm", ".git")
+	mkrepo(t, src, "gopkg.in/yaml.v2", ".git")
+	mkrepo(t, src, "example.com/standalone") // go.mod only, no VCS
+	if err := os.WriteFile(filepath.Join(src, "example.com/standalone/go.mod"), []byte("module example.com/standalone\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mkrepo(t, src, "example.com/owner/repo") // plain package dir, no VCS or go.mod
+
+	projects := Walk(root, Options{Jobs: 2})
+
+	got := importPaths(projects)
+	want := []string{"example.com/owner/repo", "example.com/standalone", "github.com/cizixs/gpm", "gopkg.in/yaml.v2"}
+	if len(got) != len(want) {
+		t.Fatalf("Walk() found %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Walk() found %v, want %v", got, want)
+		}
+	}
+	if !projects.Complete() {
+		t.Errorf("Walk().Complete() = false, want true")
+	}
+}
+
+func TestWalkSkipsVendorDirectories(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src")
+
+	mkrepo(t, src, "github.com/cizixs/gpm", ".git")
+	mkrepo(t, src, "github.com/cizixs/gpm/vendor/github.com/nested/dep", ".git")
+
+	projects := Walk(root, Options{Jobs: 2})
+
+	got := importPaths(projects)
+	want := []string{"github.com/cizixs/gpm"}
+	if len(got) != 1 || got[0] != want[0] {
+		t.Fatalf("Walk() found %v, want %v (nested vendor repo should be pruned)", got, want)
+	}
+}
+
+func TestOptionsIgnored(t *testing.T) {
+	opts := Options{Ignore: []string{"node_modules", ".cache"}}
+
+	cases := []struct {
+		dir  string
+		want bool
+	}{
+		{"/home/user/src/node_modules", true},
+		{"/home/user/src/.cache", true},
+		{"/home/user/src/github.com", false},
+	}
+
+	for _, c := range cases {
+		if got := opts.ignored(c.dir); got != c.want {
+			t.Errorf("Options{...}.ignored(%q) = %v, want %v", c.dir, got, c.want)
+		}
+	}
+}
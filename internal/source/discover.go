@@ -0,0 +1,191 @@
+package source
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"github.com/cizixs/gpm/internal/scan"
+)
+
+// cacheTTL controls how long a discovered go-import result is trusted
+// before discoverRepoURL re-fetches it.
+const cacheTTL = 7 * 24 * time.Hour
+
+type cacheEntry struct {
+	URL       string    `json:"url"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+func (e cacheEntry) expired() bool {
+	return time.Since(e.FetchedAt) > cacheTTL
+}
+
+// diskCache is a small on-disk cache of go-import discovery results,
+// keyed by module path (e.g. "example.com/foo"), backed by a JSON file
+// under the user's cache directory. A copy is kept in memory so
+// repeated lookups within a single process never touch disk twice.
+type diskCache struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	loaded  bool
+}
+
+var defaultCache = &diskCache{}
+
+func (c *diskCache) load() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.loaded {
+		return
+	}
+	c.loaded = true
+	c.entries = make(map[string]cacheEntry)
+
+	if c.path == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return
+		}
+		c.path = filepath.Join(dir, "gpm", "source-cache.json")
+	}
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return
+	}
+	json.Unmarshal(data, &c.entries)
+}
+
+func (c *diskCache) get(key string) (string, bool) {
+	c.load()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || entry.expired() {
+		return "", false
+	}
+	return entry.URL, true
+}
+
+func (c *diskCache) set(key, url string) {
+	c.load()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cacheEntry{URL: url, FetchedAt: time.Now()}
+
+	data, err := json.Marshal(c.entries)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return
+	}
+	os.WriteFile(c.path, data, 0o644)
+}
+
+// discoverRepoURL resolves repo's upstream URL via `<meta
+// name="go-import">` discovery, the mechanism `go get` itself uses for
+// vanity import paths. Results are cached on disk, keyed by module
+// path, since every lookup otherwise requires an HTTP round trip.
+func discoverRepoURL(repo scan.Repo) (string, error) {
+	modulePath := repo.ImportPath()
+
+	if url, ok := defaultCache.get(modulePath); ok {
+		return url, nil
+	}
+
+	url, err := fetchGoImport(modulePath)
+	if err != nil {
+		return "", err
+	}
+
+	defaultCache.set(modulePath, url)
+	return url, nil
+}
+
+// fetchGoImport fetches `https://<modulePath>?go-get=1` and extracts the
+// repo root URL from the `<meta name="go-import">` tag, as documented
+// at https://go.dev/cmd/go/#hdr-Remote_import_paths.
+func fetchGoImport(modulePath string) (string, error) {
+	resp, err := http.Get(fmt.Sprintf("https://%s?go-get=1", modulePath))
+	if err != nil {
+		return "", fmt.Errorf("fetching go-import meta tag for %s: %w", modulePath, err)
+	}
+	defer resp.Body.Close()
+
+	repoRoot, err := parseGoImportMeta(resp.Body, modulePath)
+	if err != nil {
+		return "", err
+	}
+	return repoRoot, nil
+}
+
+// parseGoImportMeta walks an HTML document looking for a `<meta
+// name="go-import" content="<prefix> <vcs> <repo-root>">` tag whose
+// prefix matches modulePath, and returns its repo-root.
+func parseGoImportMeta(body io.Reader, modulePath string) (string, error) {
+	doc, err := html.Parse(body)
+	if err != nil {
+		return "", fmt.Errorf("parsing go-import response for %s: %w", modulePath, err)
+	}
+
+	var repoRoot string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if repoRoot != "" {
+			return
+		}
+		if n.Type == html.ElementNode && n.Data == "meta" {
+			var name, content string
+			for _, attr := range n.Attr {
+				switch attr.Key {
+				case "name":
+					name = attr.Val
+				case "content":
+					content = attr.Val
+				}
+			}
+			if name == "go-import" {
+				if root := parseGoImportContent(content, modulePath); root != "" {
+					repoRoot = root
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	if repoRoot == "" {
+		return "", fmt.Errorf("no go-import meta tag found for %s", modulePath)
+	}
+	return repoRoot, nil
+}
+
+// parseGoImportContent parses a go-import meta tag's content attribute
+// ("<prefix> <vcs> <repo-root>") and returns repo-root if prefix
+// matches modulePath.
+func parseGoImportContent(content, modulePath string) string {
+	var prefix, vcs, repoRoot string
+	n, err := fmt.Sscanf(content, "%s %s %s", &prefix, &vcs, &repoRoot)
+	if err != nil || n != 3 {
+		return ""
+	}
+	if prefix != modulePath {
+		return ""
+	}
+	return repoRoot
+}
@@ -0,0 +1,98 @@
+package source
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseGoImportContent(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		modulePath string
+		want       string
+	}{
+		{
+			name:       "matching prefix",
+			content:    "example.com/foo git https://github.com/owner/foo",
+			modulePath: "example.com/foo",
+			want:       "https://github.com/owner/foo",
+		},
+		{
+			name:       "mismatched prefix",
+			content:    "example.com/bar git https://github.com/owner/bar",
+			modulePath: "example.com/foo",
+			want:       "",
+		},
+		{
+			name:       "too few fields",
+			content:    "example.com/foo git",
+			modulePath: "example.com/foo",
+			want:       "",
+		},
+		{
+			name:       "empty content",
+			content:    "",
+			modulePath: "example.com/foo",
+			want:       "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseGoImportContent(tt.content, tt.modulePath); got != tt.want {
+				t.Errorf("parseGoImportContent(%q, %q) = %q, want %q", tt.content, tt.modulePath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGoImportMeta(t *testing.T) {
+	const modulePath = "example.com/foo"
+
+	tests := []struct {
+		name    string
+		html    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "matching meta tag",
+			html: `<html><head>
+				<meta name="go-import" content="example.com/foo git https://github.com/owner/foo">
+				</head><body></body></html>`,
+			want: "https://github.com/owner/foo",
+		},
+		{
+			name: "picks the matching tag among several",
+			html: `<html><head>
+				<meta name="go-import" content="example.com/other git https://github.com/owner/other">
+				<meta name="go-import" content="example.com/foo git https://github.com/owner/foo">
+				</head><body></body></html>`,
+			want: "https://github.com/owner/foo",
+		},
+		{
+			name:    "no go-import meta tag",
+			html:    `<html><head><title>nothing here</title></head><body></body></html>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseGoImportMeta(strings.NewReader(tt.html), modulePath)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseGoImportMeta() error = nil, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseGoImportMeta() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("parseGoImportMeta() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Package source resolves a local goRepo into links to its upstream
+// repository: the repo's home page, a specific file, and a specific
+// line in that file. Well-known hosts (GitHub, GitLab, Bitbucket,
+// Gitea, sr.ht) are resolved from a static table; anything else falls
+// back to `<meta name="go-import">` discovery, the same approach
+// pkgsite's internal/source uses for vanity import paths.
+package source
+
+import (
+	"fmt"
+
+	"github.com/cizixs/gpm/internal/scan"
+)
+
+// hostTemplate describes how to build URLs for a known Git hosting
+// service. file and line use fmt verbs for (owner, repo, path) and
+// (owner, repo, path, line) respectively.
+type hostTemplate struct {
+	repo string
+	file string
+	line string
+}
+
+var knownHosts = map[string]hostTemplate{
+	"github.com": {
+		repo: "https://github.com/%s/%s",
+		file: "https://github.com/%s/%s/blob/master/%s",
+		line: "https://github.com/%s/%s/blob/master/%s#L%d",
+	},
+	"gitlab.com": {
+		repo: "https://gitlab.com/%s/%s",
+		file: "https://gitlab.com/%s/%s/-/blob/master/%s",
+		line: "https://gitlab.com/%s/%s/-/blob/master/%s#L%d",
+	},
+	"bitbucket.org": {
+		repo: "https://bitbucket.org/%s/%s",
+		file: "https://bitbucket.org/%s/%s/src/master/%s",
+		line: "https://bitbucket.org/%s/%s/src/master/%s#lines-%d",
+	},
+	"git.sr.ht": {
+		repo: "https://git.sr.ht/~%s/%s",
+		file: "https://git.sr.ht/~%s/%s/tree/master/item/%s",
+		line: "https://git.sr.ht/~%s/%s/tree/master/item/%s#L%d",
+	},
+	// codeberg.org is a public Gitea instance; self-hosted Gitea
+	// instances on other domains fall back to go-import discovery.
+	"codeberg.org": {
+		repo: "https://codeberg.org/%s/%s",
+		file: "https://codeberg.org/%s/%s/src/branch/master/%s",
+		line: "https://codeberg.org/%s/%s/src/branch/master/%s#L%d",
+	},
+}
+
+// RepoURL returns the URL of repo's upstream repository home page.
+func RepoURL(repo scan.Repo) (string, error) {
+	if tpl, ok := knownHosts[repo.Source]; ok {
+		return fmt.Sprintf(tpl.repo, repo.Owner, repo.Repo), nil
+	}
+	return discoverRepoURL(repo)
+}
+
+// FileURL returns the URL of file inside repo. If line is greater than
+// zero, the URL points directly at that line.
+func FileURL(repo scan.Repo, file string, line int) (string, error) {
+	if tpl, ok := knownHosts[repo.Source]; ok {
+		if line > 0 {
+			return fmt.Sprintf(tpl.line, repo.Owner, repo.Repo, file, line), nil
+		}
+		return fmt.Sprintf(tpl.file, repo.Owner, repo.Repo, file), nil
+	}
+
+	repoURL, err := discoverRepoURL(repo)
+	if err != nil {
+		return "", err
+	}
+	// Custom domains discovered via go-import meta tags don't have a
+	// known file/line URL scheme, so the best we can offer is the repo
+	// home page.
+	return repoURL, nil
+}
@@ -0,0 +1,111 @@
+package source
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cizixs/gpm/internal/scan"
+)
+
+// withTempCache swaps defaultCache for an already-loaded, pre-seeded
+// cache backed by a temp file, so tests exercising the discovery
+// fallback never hit the network or the real on-disk cache. The
+// original is restored once the test finishes.
+func withTempCache(t *testing.T, entries map[string]cacheEntry) {
+	t.Helper()
+	orig := defaultCache
+	t.Cleanup(func() { defaultCache = orig })
+
+	if entries == nil {
+		entries = make(map[string]cacheEntry)
+	}
+	defaultCache = &diskCache{
+		path:    filepath.Join(t.TempDir(), "source-cache.json"),
+		entries: entries,
+		loaded:  true,
+	}
+}
+
+func TestRepoURLKnownHosts(t *testing.T) {
+	tests := []struct {
+		name string
+		repo scan.Repo
+		want string
+	}{
+		{"github.com", scan.Repo{Source: "github.com", Owner: "cizixs", Repo: "gpm"}, "https://github.com/cizixs/gpm"},
+		{"gitlab.com", scan.Repo{Source: "gitlab.com", Owner: "owner", Repo: "repo"}, "https://gitlab.com/owner/repo"},
+		{"bitbucket.org", scan.Repo{Source: "bitbucket.org", Owner: "owner", Repo: "repo"}, "https://bitbucket.org/owner/repo"},
+		{"git.sr.ht", scan.Repo{Source: "git.sr.ht", Owner: "owner", Repo: "repo"}, "https://git.sr.ht/~owner/repo"},
+		{"codeberg.org", scan.Repo{Source: "codeberg.org", Owner: "owner", Repo: "repo"}, "https://codeberg.org/owner/repo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RepoURL(tt.repo)
+			if err != nil {
+				t.Fatalf("RepoURL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RepoURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileURLKnownHosts(t *testing.T) {
+	tests := []struct {
+		name string
+		repo scan.Repo
+		file string
+		line int
+		want string
+	}{
+		{"github.com no line", scan.Repo{Source: "github.com", Owner: "cizixs", Repo: "gpm"}, "main.go", 0, "https://github.com/cizixs/gpm/blob/master/main.go"},
+		{"github.com with line", scan.Repo{Source: "github.com", Owner: "cizixs", Repo: "gpm"}, "main.go", 42, "https://github.com/cizixs/gpm/blob/master/main.go#L42"},
+		{"gitlab.com with line", scan.Repo{Source: "gitlab.com", Owner: "owner", Repo: "repo"}, "pkg/x.go", 7, "https://gitlab.com/owner/repo/-/blob/master/pkg/x.go#L7"},
+		{"bitbucket.org with line", scan.Repo{Source: "bitbucket.org", Owner: "owner", Repo: "repo"}, "x.go", 3, "https://bitbucket.org/owner/repo/src/master/x.go#lines-3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FileURL(tt.repo, tt.file, tt.line)
+			if err != nil {
+				t.Fatalf("FileURL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FileURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepoURLUnknownHostUsesDiscoveryCache(t *testing.T) {
+	repo := scan.Repo{Source: "example.com", Repo: "foo"}
+	withTempCache(t, map[string]cacheEntry{
+		repo.ImportPath(): {URL: "https://github.com/owner/foo", FetchedAt: time.Now()},
+	})
+
+	got, err := RepoURL(repo)
+	if err != nil {
+		t.Fatalf("RepoURL() error = %v", err)
+	}
+	if want := "https://github.com/owner/foo"; got != want {
+		t.Errorf("RepoURL() = %q, want %q", got, want)
+	}
+}
+
+func TestFileURLUnknownHostFallsBackToRepoURL(t *testing.T) {
+	repo := scan.Repo{Source: "example.com", Repo: "foo"}
+	withTempCache(t, map[string]cacheEntry{
+		repo.ImportPath(): {URL: "https://github.com/owner/foo", FetchedAt: time.Now()},
+	})
+
+	got, err := FileURL(repo, "main.go", 10)
+	if err != nil {
+		t.Fatalf("FileURL() error = %v", err)
+	}
+	if want := "https://github.com/owner/foo"; got != want {
+		t.Errorf("FileURL() = %q, want %q (custom hosts have no known file/line scheme)", got, want)
+	}
+}